@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const baseURL = "http://localhost:8001"
+
+// addTodo adds a todo item to the given list by calling the server's
+// create endpoint.
+func addTodo(listName string, todo string) {
+	body := struct {
+		List     string `json:"list"`
+		TodoBody string `json:"todo"`
+	}{
+		List:     listName,
+		TodoBody: todo,
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "addTodo: marshaling request: %v\n", err)
+		os.Exit(1)
+	}
+
+	resp, err := http.Post(baseURL+"/todos", "application/json", bytes.NewReader(data))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "addTodo: POST to %s failed: %v\n", baseURL+"/todos", err)
+		os.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	io.Copy(os.Stdout, resp.Body)
+	fmt.Printf("\nStatus: %v\n", resp.Status)
+}
+
+func testConnection() {
+	// ping server
+	fmt.Println("testing connection...not really")
+}
+
+// getTodos requests a dump of all todos saved to the server
+func getTodos() {
+	resp, err := http.Get(baseURL + "/todos")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "getTodos: %v\n", err)
+		os.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(os.Stdout, resp.Body)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "getTodos: reading response: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\nStatus: %v\n", resp.Status)
+}
+
+// doneTodo marks the todo with the given id as completed.
+func doneTodo(id string) {
+	patchTodo(id, struct {
+		Completed bool `json:"completed"`
+	}{Completed: true})
+}
+
+// editTodo changes the body of the todo with the given id.
+func editTodo(id string, body string) {
+	patchTodo(id, struct {
+		Body string `json:"body"`
+	}{Body: body})
+}
+
+// patchTodo sends the given payload as a PUT to /todos/{id}.
+func patchTodo(id string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "patchTodo: marshaling request: %v\n", err)
+		os.Exit(1)
+	}
+
+	url := baseURL + "/todos/" + id
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "patchTodo: building request: %v\n", err)
+		os.Exit(1)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "patchTodo: PUT to %s failed: %v\n", url, err)
+		os.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	io.Copy(os.Stdout, resp.Body)
+	fmt.Printf("\nStatus: %v\n", resp.Status)
+}
+
+// rmTodo deletes the todo with the given id.
+func rmTodo(id string) {
+	url := baseURL + "/todos/" + id
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rmTodo: building request: %v\n", err)
+		os.Exit(1)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rmTodo: DELETE to %s failed: %v\n", url, err)
+		os.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	io.Copy(os.Stdout, resp.Body)
+	fmt.Printf("\nStatus: %v\n", resp.Status)
+}
+
+// fetch makes a GET request to the supplied url strings (args) and
+// prints the resulting response body, or an error.
+func fetch(args []string) {
+	for _, url := range args {
+		url = ensureProtocol(url)
+
+		resp, err := http.Get(url)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fetch: %v\n", err)
+			os.Exit(1)
+		}
+		defer resp.Body.Close()
+
+		_, err = io.Copy(os.Stdout, resp.Body)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fetch: reading %s: %v\n", url, err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("\nStatus: %v\n", resp.Status)
+	}
+}
+
+// ensureProtocol adds the http:// protocol to a supplied url if it is
+// not present
+func ensureProtocol(url string) string {
+	protocol := "http://"
+	if !strings.HasPrefix(url, protocol) {
+		return protocol + url
+	}
+
+	return url
+}
+
+// fetchall asyncronously fetches data from all urls supplied in
+// args
+func fetchall(args []string) {
+	start := time.Now()
+	ch := make(chan string)
+	for _, url := range args {
+		go fetchC(url, ch) // start a goroutine
+	}
+	for range args {
+		fmt.Println(<-ch) // receive from channel ch
+	}
+	fmt.Printf("%.2fs elapsed\n", time.Since(start).Seconds())
+}
+
+// fetchC makes a GET request to a supplied url and writes a summary
+// or an error to channel ch
+func fetchC(url string, ch chan<- string) {
+	start := time.Now()
+	resp, err := http.Get(url)
+	if err != nil {
+		ch <- fmt.Sprint(err) // send to channel ch
+		return
+	}
+	defer resp.Body.Close()
+
+	nbytes, err := io.Copy(ioutil.Discard, resp.Body)
+	if err != nil {
+		ch <- fmt.Sprintf("while reading %s: %v", url, err)
+		return
+	}
+
+	secs := time.Since(start).Seconds()
+	ch <- fmt.Sprintf("%.2fs	%7d	%s", secs, nbytes, url)
+}
@@ -0,0 +1,77 @@
+// Package errors defines godo's structured, machine-readable API error
+// format, modeled on etcd's error package: a stable numeric code plus a
+// human-readable message and a request-specific cause, marshaled
+// straight to the response body instead of a bare 500.
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Error is the JSON shape returned for every failed API request.
+type Error struct {
+	ErrorCode int    `json:"errorCode"`
+	Message   string `json:"message"`
+	Cause     string `json:"cause,omitempty"`
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("%s: %s (%d)", e.Message, e.Cause, e.ErrorCode)
+}
+
+// Error codes. New codes should be added here alongside their message
+// in errorCodeToMessage, and never reused for a different meaning.
+const (
+	EcodeListNotFound  = 100
+	EcodeTodoNotFound  = 101
+	EcodeMalformedJSON = 200
+	EcodeTooManyItems  = 103
+	EcodeInternal      = 300
+)
+
+var errorCodeToMessage = map[int]string{
+	EcodeListNotFound:  "List not found",
+	EcodeTodoNotFound:  "Todo not found",
+	EcodeMalformedJSON: "Malformed JSON",
+	EcodeTooManyItems:  "Too many items",
+	EcodeInternal:      "Internal error",
+}
+
+var errorCodeToStatus = map[int]int{
+	EcodeListNotFound:  http.StatusNotFound,
+	EcodeTodoNotFound:  http.StatusNotFound,
+	EcodeMalformedJSON: http.StatusBadRequest,
+	EcodeTooManyItems:  http.StatusBadRequest,
+	EcodeInternal:      http.StatusInternalServerError,
+}
+
+// New builds an Error for the given code and cause, looking up the
+// code's message in the registry.
+func New(code int, cause string) Error {
+	return Error{
+		ErrorCode: code,
+		Message:   errorCodeToMessage[code],
+		Cause:     cause,
+	}
+}
+
+// Write marshals e as the response body and sets the HTTP status that
+// matches e.ErrorCode (500 for an unregistered code).
+func (e Error) Write(w http.ResponseWriter) {
+	status, ok := errorCodeToStatus[e.ErrorCode]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(e)
+}
+
+// WriteError is shorthand for New(code, cause).Write(w), which is how
+// every handler should report a failure.
+func WriteError(w http.ResponseWriter, code int, cause string) {
+	New(code, cause).Write(w)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+)
+
+// crashed is closed if any background goroutine spawned by the server
+// (a future scheduler or reminder worker, say) exits unexpectedly.
+// /readyz starts failing once it's closed.
+var crashed = make(chan struct{})
+var crashedOnce sync.Once
+
+// spawnSupervised runs fn in its own goroutine and closes crashed if fn
+// ever returns, so /readyz can flip to 503 instead of the server
+// silently running without that worker.
+func spawnSupervised(name string, fn func() error) {
+	go func() {
+		err := fn()
+		log.Printf("%s exited: %v", name, err)
+		crashedOnce.Do(func() { close(crashed) })
+	}()
+}
+
+// healthz is a liveness probe: if the process can answer HTTP at all,
+// it's alive.
+func healthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "ok")
+}
+
+// readyz is a readiness probe: it also fails if the storage backend is
+// unreachable or a supervised background goroutine has crashed.
+func readyz(w http.ResponseWriter, r *http.Request) {
+	select {
+	case <-crashed:
+		http.Error(w, "a background worker has crashed", http.StatusServiceUnavailable)
+		return
+	default:
+	}
+
+	if p, ok := store.(interface{ Ping() error }); ok {
+		if err := p.Ping(); err != nil {
+			http.Error(w, fmt.Sprintf("store unreachable: %v", err), http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "ok")
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/mux"
+	apierrors "github.com/vvmk/godo/errors"
+)
+
+var mu sync.Mutex
+var count int
+
+var storeFlag = flag.String("store", "memory", "storage backend for the server: memory or redis")
+var redisAddr = flag.String("redis-addr", "localhost:6379", "redis address used when -store=redis")
+
+// store is the backend every handler reads and writes todos through.
+// It's selected in startServer based on -store.
+var store Store
+
+// startServer starts a server that will receive and store todo messages
+// and respond to list queries from godo clients
+func startServer() {
+	switch *storeFlag {
+	case "memory":
+		store = newMemoryStore()
+	case "redis":
+		rs := newRedisStore(*redisAddr)
+		if err := rs.Ping(); err != nil {
+			log.Fatalf("startServer: redis unreachable at %s: %v", *redisAddr, err)
+		}
+		store = rs
+	default:
+		log.Fatalf("startServer: unknown -store %q (want memory or redis)", *storeFlag)
+	}
+
+	r := mux.NewRouter()
+
+	r.HandleFunc("/echo", handler) // each request echos back its path
+	r.HandleFunc("/count", counter)
+	r.HandleFunc("/request", request)
+
+	r.HandleFunc("/healthz", healthz)
+	r.HandleFunc("/readyz", readyz)
+
+	r.HandleFunc("/todos", listTodos).Methods(http.MethodGet)
+	r.HandleFunc("/todos", createTodo).Methods(http.MethodPost)
+	r.HandleFunc("/todos/{id}", getTodo).Methods(http.MethodGet)
+	r.HandleFunc("/todos/{id}", updateTodo).Methods(http.MethodPut)
+	r.HandleFunc("/todos/{id}", deleteTodo).Methods(http.MethodDelete)
+
+	// Catch-all: the static UI. Registered last so it doesn't shadow
+	// the API routes above.
+	r.PathPrefix("/").Handler(uiHandler())
+
+	log.Fatal(http.ListenAndServe("localhost:8001", r))
+}
+
+// handler just echos back the url path
+func handler(w http.ResponseWriter, r *http.Request) {
+	mu.Lock()
+	count++
+	mu.Unlock()
+
+	fmt.Fprintf(w, "URL.Path = %q\n", r.URL.Path)
+}
+
+// counter echos the number of incoming requests
+func counter(w http.ResponseWriter, r *http.Request) {
+	mu.Lock()
+	fmt.Fprintf(w, "Count %d\n", count)
+	mu.Unlock()
+}
+
+// request echos the requests headers and form data for debugging calls
+func request(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, "%s %s %s\n", r.Method, r.URL, r.Proto)
+	for k, v := range r.Header {
+		fmt.Fprintf(w, "Header[%q] = %q\n", k, v)
+	}
+
+	fmt.Fprintf(w, "Host = %q\n", r.Host)
+	fmt.Fprintf(w, "RemoteAddr = %q\n", r.RemoteAddr)
+
+	if err := r.ParseForm(); err != nil {
+		log.Print(err)
+	}
+
+	for k, v := range r.Form {
+		fmt.Fprintf(w, "Form[%q] = %q\n", k, v)
+	}
+}
+
+// createTodo handles POST /todos, adding a new todo to the named list.
+func createTodo(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var b struct {
+		List string `json:"list"`
+		Todo string `json:"todo"`
+	}
+
+	if err := decoder.Decode(&b); err != nil {
+		apierrors.WriteError(w, apierrors.EcodeMalformedJSON, err.Error())
+		return
+	}
+
+	t, err := store.Add(b.List, b.Todo)
+	if err != nil {
+		apierrors.WriteError(w, apierrors.EcodeInternal, err.Error())
+		return
+	}
+
+	log.Printf("added todo: '%s' to list: '%s' at %v", t.Body, t.List, t.CreatedAt)
+
+	tData, err := json.Marshal(t)
+	if err != nil {
+		apierrors.WriteError(w, apierrors.EcodeInternal, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(tData)
+}
+
+// listTodos handles GET /todos, returning every list of todos.
+func listTodos(w http.ResponseWriter, r *http.Request) {
+	lists, err := store.All()
+	if err != nil {
+		apierrors.WriteError(w, apierrors.EcodeInternal, err.Error())
+		return
+	}
+
+	rString, err := json.Marshal(lists)
+	if err != nil {
+		apierrors.WriteError(w, apierrors.EcodeInternal, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(rString)
+}
+
+// getTodo handles GET /todos/{id}, returning a single todo by id.
+func getTodo(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		apierrors.WriteError(w, apierrors.EcodeMalformedJSON, err.Error())
+		return
+	}
+
+	t, err := store.Get(id)
+	if err == ErrNotFound {
+		apierrors.WriteError(w, apierrors.EcodeTodoNotFound, fmt.Sprintf("todo %d", id))
+		return
+	}
+	if err != nil {
+		apierrors.WriteError(w, apierrors.EcodeInternal, err.Error())
+		return
+	}
+
+	tData, err := json.Marshal(t)
+	if err != nil {
+		apierrors.WriteError(w, apierrors.EcodeInternal, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(tData)
+}
+
+// updateTodo handles PUT /todos/{id}, toggling Completed and/or editing
+// Body. Fields left out of the request body are left unchanged.
+func updateTodo(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		apierrors.WriteError(w, apierrors.EcodeMalformedJSON, err.Error())
+		return
+	}
+
+	var b struct {
+		Body      *string `json:"body"`
+		Completed *bool   `json:"completed"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&b); err != nil {
+		apierrors.WriteError(w, apierrors.EcodeMalformedJSON, err.Error())
+		return
+	}
+
+	t, err := store.Update(id, b.Body, b.Completed)
+	if err == ErrNotFound {
+		apierrors.WriteError(w, apierrors.EcodeTodoNotFound, fmt.Sprintf("todo %d", id))
+		return
+	}
+	if err != nil {
+		apierrors.WriteError(w, apierrors.EcodeInternal, err.Error())
+		return
+	}
+
+	tData, err := json.Marshal(t)
+	if err != nil {
+		apierrors.WriteError(w, apierrors.EcodeInternal, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(tData)
+}
+
+// deleteTodo handles DELETE /todos/{id}, removing a todo from its list.
+func deleteTodo(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		apierrors.WriteError(w, apierrors.EcodeMalformedJSON, err.Error())
+		return
+	}
+
+	err = store.Delete(id)
+	if err == ErrNotFound {
+		apierrors.WriteError(w, apierrors.EcodeTodoNotFound, fmt.Sprintf("todo %d", id))
+		return
+	}
+	if err != nil {
+		apierrors.WriteError(w, apierrors.EcodeInternal, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,29 @@
+package main
+
+import "errors"
+
+// ErrNotFound is returned by Store implementations when no todo
+// matches the requested id.
+var ErrNotFound = errors.New("todo not found")
+
+// Store is the persistence boundary between the HTTP handlers and
+// wherever todos actually live. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Add creates a new todo on the given list and returns it with its
+	// assigned Id.
+	Add(list, body string) (Todo, error)
+
+	// All returns every todo, grouped by list name.
+	All() (map[string][]Todo, error)
+
+	// Get returns the todo with the given id, or ErrNotFound.
+	Get(id int) (Todo, error)
+
+	// Update applies body and completed (when non-nil) to the todo with
+	// the given id and returns the updated todo, or ErrNotFound.
+	Update(id int, body *string, completed *bool) (Todo, error)
+
+	// Delete removes the todo with the given id, or returns ErrNotFound.
+	Delete(id int) error
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// memoryStore is the original, process-lifetime-only Store backed by a
+// map of list name to todos. Todos are lost on restart. mu guards
+// lists against concurrent handler goroutines.
+type memoryStore struct {
+	mu     sync.RWMutex
+	lists  map[string][]Todo
+	nextID int64
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{lists: make(map[string][]Todo)}
+}
+
+func (s *memoryStore) Add(list, body string) (Todo, error) {
+	t := Todo{
+		Id:        int(atomic.AddInt64(&s.nextID, 1)),
+		List:      list,
+		Body:      body,
+		Completed: false,
+		CreatedAt: time.Now(),
+	}
+
+	s.mu.Lock()
+	s.lists[list] = append(s.lists[list], t)
+	s.mu.Unlock()
+
+	return t, nil
+}
+
+func (s *memoryStore) All() (map[string][]Todo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	lists := make(map[string][]Todo, len(s.lists))
+	for list, todos := range s.lists {
+		cp := make([]Todo, len(todos))
+		copy(cp, todos)
+		lists[list] = cp
+	}
+
+	return lists, nil
+}
+
+func (s *memoryStore) Get(id int) (Todo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for list := range s.lists {
+		for _, t := range s.lists[list] {
+			if t.Id == id {
+				return t, nil
+			}
+		}
+	}
+
+	return Todo{}, ErrNotFound
+}
+
+func (s *memoryStore) Update(id int, body *string, completed *bool) (Todo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for list := range s.lists {
+		for i := range s.lists[list] {
+			if s.lists[list][i].Id != id {
+				continue
+			}
+
+			if body != nil {
+				s.lists[list][i].Body = *body
+			}
+			if completed != nil {
+				s.lists[list][i].Completed = *completed
+			}
+
+			return s.lists[list][i], nil
+		}
+	}
+
+	return Todo{}, ErrNotFound
+}
+
+func (s *memoryStore) Delete(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for list := range s.lists {
+		for i := range s.lists[list] {
+			if s.lists[list][i].Id == id {
+				s.lists[list] = append(s.lists[list][:i], s.lists[list][i+1:]...)
+				return nil
+			}
+		}
+	}
+
+	return ErrNotFound
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestConcurrentCreateAndList hammers POST and GET /todos from many
+// goroutines at once. Run with `go test -race` to confirm the
+// memoryStore's locking actually guards against concurrent access.
+func TestConcurrentCreateAndList(t *testing.T) {
+	store = newMemoryStore()
+
+	r := mux.NewRouter()
+	r.HandleFunc("/todos", listTodos).Methods(http.MethodGet)
+	r.HandleFunc("/todos", createTodo).Methods(http.MethodPost)
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+
+			body := fmt.Sprintf(`{"list":"Inbox","todo":"item %d"}`, i)
+			resp, err := http.Post(srv.URL+"/todos", "application/json", strings.NewReader(body))
+			if err != nil {
+				t.Errorf("create: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			resp, err := http.Get(srv.URL + "/todos")
+			if err != nil {
+				t.Errorf("list: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// redisStore persists todos in Redis, one key per todo (todo:<id>), so
+// that they survive server restarts. It mirrors the approach used by
+// the gotodo example: JSON-encoded values, scanned back with SCAN.
+type redisStore struct {
+	rdb *redis.Client
+	ctx context.Context
+}
+
+func newRedisStore(addr string) *redisStore {
+	return &redisStore{
+		rdb: redis.NewClient(&redis.Options{Addr: addr}),
+		ctx: context.Background(),
+	}
+}
+
+// Ping checks that Redis is reachable.
+func (s *redisStore) Ping() error {
+	return s.rdb.Ping(s.ctx).Err()
+}
+
+func (s *redisStore) key(id int) string {
+	return fmt.Sprintf("todo:%d", id)
+}
+
+func (s *redisStore) Add(list, body string) (Todo, error) {
+	id, err := s.rdb.Incr(s.ctx, "todo:nextid").Result()
+	if err != nil {
+		return Todo{}, fmt.Errorf("redisStore: allocating id: %w", err)
+	}
+
+	t := Todo{
+		Id:        int(id),
+		List:      list,
+		Body:      body,
+		Completed: false,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.put(t); err != nil {
+		return Todo{}, err
+	}
+
+	return t, nil
+}
+
+func (s *redisStore) put(t Todo) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("redisStore: marshaling todo %d: %w", t.Id, err)
+	}
+
+	return s.rdb.Set(s.ctx, s.key(t.Id), data, 0).Err()
+}
+
+func (s *redisStore) All() (map[string][]Todo, error) {
+	lists := make(map[string][]Todo)
+
+	iter := s.rdb.Scan(s.ctx, 0, "todo:*", 0).Iterator()
+	for iter.Next(s.ctx) {
+		key := iter.Val()
+		if key == "todo:nextid" {
+			continue
+		}
+
+		t, err := s.getKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		lists[t.List] = append(lists[t.List], t)
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("redisStore: scanning: %w", err)
+	}
+
+	return lists, nil
+}
+
+func (s *redisStore) getKey(key string) (Todo, error) {
+	data, err := s.rdb.Get(s.ctx, key).Bytes()
+	if err == redis.Nil {
+		return Todo{}, err
+	}
+	if err != nil {
+		return Todo{}, fmt.Errorf("redisStore: reading %s: %w", key, err)
+	}
+
+	var t Todo
+	if err := json.Unmarshal(data, &t); err != nil {
+		return Todo{}, fmt.Errorf("redisStore: decoding %s: %w", key, err)
+	}
+
+	return t, nil
+}
+
+func (s *redisStore) Get(id int) (Todo, error) {
+	t, err := s.getKey(s.key(id))
+	if err == redis.Nil {
+		return Todo{}, ErrNotFound
+	}
+
+	return t, err
+}
+
+func (s *redisStore) Update(id int, body *string, completed *bool) (Todo, error) {
+	t, err := s.Get(id)
+	if err != nil {
+		return Todo{}, err
+	}
+
+	if body != nil {
+		t.Body = *body
+	}
+	if completed != nil {
+		t.Completed = *completed
+	}
+
+	if err := s.put(t); err != nil {
+		return Todo{}, err
+	}
+
+	return t, nil
+}
+
+func (s *redisStore) Delete(id int) error {
+	n, err := s.rdb.Del(s.ctx, s.key(id)).Result()
+	if err != nil {
+		return fmt.Errorf("redisStore: deleting %d: %w", id, err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package main
+
+import "time"
+
+// A Todo is a thing I need to do...go figure.
+type Todo struct {
+	Id        int       `json:"id"`
+	List      string    `json:"list"`
+	Body      string    `json:"body"`
+	Completed bool      `json:"completed"`
+	CreatedAt time.Time `json:"created_at"`
+}
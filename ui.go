@@ -0,0 +1,24 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"log"
+	"net/http"
+)
+
+//go:embed web
+var webFS embed.FS
+
+// uiHandler serves the static single-page UI embedded from web/ at
+// build time. It lists todos per list, lets the user check them off,
+// and adds new ones, all by calling the same REST endpoints the CLI
+// uses.
+func uiHandler() http.Handler {
+	sub, err := fs.Sub(webFS, "web")
+	if err != nil {
+		log.Fatalf("uiHandler: %v", err)
+	}
+
+	return http.FileServer(http.FS(sub))
+}